@@ -15,12 +15,19 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aurora-scheduler/australis/internal"
 	realis "github.com/aurora-scheduler/gorealis/v2"
@@ -29,19 +36,226 @@ import (
 	"github.com/spf13/pflag"
 )
 
-const (
-	localAgentStateURL = "http://127.0.0.1:5051/state"
+// outputFormat holds the value of the --output/-o flag shared by all fetch
+// sub-commands. Valid values are "json" (default), "yaml", and "table".
+var outputFormat string
+
+// watch, watchInterval, and watchOnChange back the --watch/-w, --interval,
+// and --on-change flags shared by the fetch sub-commands that support
+// streaming diffs (task status, jobs).
+var (
+	watch         bool
+	watchInterval time.Duration
+	watchOnChange string
 )
 
+// taskTable adapts a slice of Aurora tasks to internal.Tabular for
+// --output table.
+type taskTable []*aurora.ScheduledTask
+
+func (t taskTable) TableHeader() []string { return []string{"task", "job", "status"} }
+
+func (t taskTable) TableRows() [][]string {
+	rows := make([][]string, 0, len(t))
+	for _, task := range t {
+		assigned := task.GetAssignedTask()
+		rows = append(rows, []string{
+			taskID(assigned),
+			assigned.GetTask().GetJob().String(),
+			task.GetStatus().String(),
+		})
+	}
+	return rows
+}
+
+// taskID returns the Mesos task ID for assigned, falling back to its
+// instance ID when the task ID hasn't been populated (e.g. a PENDING task).
+func taskID(assigned *aurora.AssignedTask) string {
+	if id := assigned.GetTaskId(); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%d", assigned.GetInstanceId())
+}
+
+// hostStatusTable adapts a slice of host maintenance statuses to
+// internal.Tabular for --output table.
+type hostStatusTable []*aurora.HostStatus
+
+func (h hostStatusTable) TableHeader() []string { return []string{"host", "status"} }
+
+func (h hostStatusTable) TableRows() [][]string {
+	rows := make([][]string, 0, len(h))
+	for _, status := range h {
+		rows = append(rows, []string{status.GetHost(), status.GetMode().String()})
+	}
+	return rows
+}
+
+// jobTable adapts a slice of job configurations to internal.Tabular for
+// --output table.
+type jobTable []*aurora.JobConfiguration
+
+func (j jobTable) TableHeader() []string { return []string{"job", "role", "instances"} }
+
+func (j jobTable) TableRows() [][]string {
+	rows := make([][]string, 0, len(j))
+	for _, config := range j {
+		rows = append(rows, []string{
+			config.GetKey().String(),
+			config.GetKey().GetRole(),
+			fmt.Sprintf("%d", config.GetInstanceCount()),
+		})
+	}
+	return rows
+}
+
+// printFormatted renders v using the --output/-o flag and prints it to
+// stdout, falling back to log.Fatalf on unsupported formats.
+func printFormatted(v interface{}) {
+	out, err := internal.Format(v, outputFormat)
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+	fmt.Println(out)
+}
+
 type mesosAgentState struct {
 	Flags mesosAgentFlags `json:"flags,omitempty"`
 }
 
 type mesosAgentFlags struct {
-	Master    string `json:"master,omitempty"`
+	Master string `json:"master,omitempty"`
+	// Auth holds the "user:pass" credentials parsed out of a zk://user:pass@...
+	// Master value. It is not part of the agent state JSON; it exists so that
+	// downstream realis.MesosFromZKOpts callers can authenticate to
+	// ACL-protected Zookeeper ensembles.
+	// TODO: wire this into realis.MesosFromZKOpts once it accepts ZK auth.
+	Auth      string `json:"-"`
 	hasMaster bool   // indicates if the master flag contains direct Master's address
 }
 
+const (
+	defaultAgentURL          = "http://127.0.0.1:5051/state"
+	agentDialTimeout         = 2 * time.Second
+	agentResponseTimeout     = 5 * time.Second
+	agentTLSHandshakeTimeout = 2 * time.Second
+)
+
+// mesosAgentOpts configures how fetchMasterFromAgent reaches the local
+// Mesos agent: connection/read timeouts, optional TLS, and optional auth.
+type mesosAgentOpts struct {
+	url string
+	// tlsConfig is non-nil when --agent-cacert or --agent-insecure-skip-verify
+	// is set. It is built from those flags only; the australis config file
+	// is not read for agent TLS settings.
+	tlsConfig *tls.Config
+	// auth is either a bearer token or a Mesos "principal:secret" pair, as
+	// passed via --mesos-agent-auth. Empty means no authentication.
+	auth string
+}
+
+// mesosAgentHTTPClient builds an http.Client with explicit dial/read
+// timeouts and the optional TLS config from opts, rather than relying on
+// http.Get's unbounded default client.
+func mesosAgentHTTPClient(opts mesosAgentOpts) *http.Client {
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: agentDialTimeout,
+		}).DialContext,
+		TLSHandshakeTimeout: agentTLSHandshakeTimeout,
+	}
+	if opts.tlsConfig != nil {
+		transport.TLSClientConfig = opts.tlsConfig
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   agentResponseTimeout,
+	}
+}
+
+// timeoutHTTPClient builds an http.Client with the same dial/response
+// timeouts as mesosAgentHTTPClient, for callers that talk to an endpoint
+// with no associated mesosAgentOpts (e.g. the Operator API or a metrics
+// scrape target) but still shouldn't hang forever on an unresponsive peer.
+func timeoutHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout: agentDialTimeout,
+			}).DialContext,
+			TLSHandshakeTimeout: agentTLSHandshakeTimeout,
+		},
+		Timeout: agentResponseTimeout,
+	}
+}
+
+// addMesosAgentFlags registers the flags used to configure how
+// fetchMasterFromAgent reaches the local Mesos agent. Shared by
+// mesosLeaderCmd and fetchMetricsCmd, which both resolve the Mesos master
+// through resolveMesosLeader.
+func addMesosAgentFlags(cmd *cobra.Command) {
+	cmd.Flags().String("agent-url", defaultAgentURL, "Mesos agent /state endpoint to query for the master, e.g. https://agent:5051/state")
+	cmd.Flags().String("mesos-agent-auth", "", "Credentials for --agent-url: a bearer token, or a Mesos \"principal:secret\" pair")
+	cmd.Flags().String("agent-cacert", "", "PEM-encoded CA certificate bundle to verify an HTTPS --agent-url against")
+	cmd.Flags().Bool("agent-insecure-skip-verify", false, "Skip TLS certificate verification for an HTTPS --agent-url (not recommended)")
+}
+
+// mesosAgentOptsFromFlags builds a mesosAgentOpts from the flags registered
+// by addMesosAgentFlags.
+func mesosAgentOptsFromFlags(cmd *cobra.Command) (mesosAgentOpts, error) {
+	opts := mesosAgentOpts{
+		url:  cmd.Flag("agent-url").Value.String(),
+		auth: cmd.Flag("mesos-agent-auth").Value.String(),
+	}
+
+	insecureSkipVerify, err := cmd.Flags().GetBool("agent-insecure-skip-verify")
+	if err != nil {
+		return opts, err
+	}
+	caCertPath := cmd.Flag("agent-cacert").Value.String()
+
+	if insecureSkipVerify || caCertPath != "" {
+		opts.tlsConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	}
+	if caCertPath != "" {
+		caCert, err := ioutil.ReadFile(caCertPath)
+		if err != nil {
+			return opts, fmt.Errorf("unable to read --agent-cacert %s: %w", caCertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return opts, fmt.Errorf("no certificates found in --agent-cacert %s", caCertPath)
+		}
+		opts.tlsConfig.RootCAs = pool
+	}
+
+	return opts, nil
+}
+
+// setMesosAgentAuth attaches --mesos-agent-auth to req, as a bearer token if
+// auth doesn't look like a Mesos "principal:secret" pair, and as HTTP basic
+// auth (the convention used for Mesos principal/secret credentials) otherwise.
+func setMesosAgentAuth(req *http.Request, auth string) {
+	if auth == "" {
+		return
+	}
+	if principal, secret, ok := strings.Cut(auth, ":"); ok {
+		req.SetBasicAuth(principal, secret)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+auth)
+}
+
+// leaderResult wraps a resolved leader URL so it can go through
+// internal.Format like every other fetch result.
+type leaderResult struct {
+	URL string `json:"url"`
+}
+
+func (l leaderResult) TableHeader() []string { return []string{"url"} }
+func (l leaderResult) TableRows() [][]string { return [][]string{{l.URL}} }
+
 func init() {
 	rootCmd.AddCommand(fetchCmd)
 
@@ -61,6 +275,9 @@ func init() {
 	taskStatusCmd.Flags().StringVarP(env, "environment", "e", "", "Aurora Environment")
 	taskStatusCmd.Flags().StringVarP(role, "role", "r", "", "Aurora Role")
 	taskStatusCmd.Flags().StringVarP(name, "name", "n", "", "Aurora Name")
+	taskStatusCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch for task state changes and stream them as newline-delimited JSON events")
+	taskStatusCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Polling interval used with --watch")
+	taskStatusCmd.Flags().StringVar(&watchOnChange, "on-change", "", "Shell command to run for every --watch event, with AURORA_TASK_ID/AURORA_OLD_STATE/AURORA_NEW_STATE set")
 
 	/* Fetch Leader */
 	leaderCmd.Flags().String("zkPath", "/aurora/scheduler", "Zookeeper node path where leader election happens")
@@ -81,6 +298,10 @@ func init() {
 	})
 
 	mesosLeaderCmd.Flags().String("zkPath", "/mesos", "Zookeeper node path where mesos leader election happens")
+	mesosLeaderCmd.Flags().String("source", "", fmt.Sprintf("Where to resolve the Mesos leader from: %s (default: try agent then zk)", strings.Join(mesosSources, ", ")))
+	mesosLeaderCmd.Flags().String("operator-endpoint", "", "Mesos master endpoint to query via the Operator HTTP API, e.g. http://mesos-master:5050/api/v1 (required when --source=operator)")
+	mesosLeaderCmd.Flags().String("dns-domain", "", "Domain to resolve _mesos._tcp SRV records against (required when --source=dns)")
+	addMesosAgentFlags(mesosLeaderCmd)
 	mesosCmd.AddCommand(mesosLeaderCmd)
 
 	fetchCmd.AddCommand(mesosCmd)
@@ -99,10 +320,16 @@ func init() {
 
 	// Fetch jobs
 	fetchJobsCmd.Flags().StringVarP(role, "role", "r", "", "Aurora Role")
+	fetchJobsCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch for new/removed jobs and stream them as newline-delimited JSON events")
+	fetchJobsCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Polling interval used with --watch")
+	fetchJobsCmd.Flags().StringVar(&watchOnChange, "on-change", "", "Shell command to run for every --watch event, with AURORA_JOB_KEY/AURORA_OLD_STATE/AURORA_NEW_STATE set")
 	fetchCmd.AddCommand(fetchJobsCmd)
 
 	// Fetch Status
 	fetchCmd.AddCommand(fetchStatusCmd)
+
+	// Output format shared by every fetch sub-command.
+	fetchCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format: json, yaml, or table")
 }
 
 var fetchCmd = &cobra.Command{
@@ -154,8 +381,12 @@ var mesosLeaderCmd = &cobra.Command{
 	PreRun:            setConfig,
 	Short:             "Fetch current Mesos-master leader given Zookeeper nodes.",
 	Long: `Gets the current leading Mesos-master instance using information from Zookeeper path.
-Pass Zookeeper nodes separated by a space as an argument to this command. If no nodes are provided, 
-it fetches leader from local Mesos agent or Zookeeper`,
+Pass Zookeeper nodes separated by a space as an argument to this command. If no nodes are provided,
+it fetches leader from local Mesos agent or Zookeeper.
+
+Use --source to pick a specific resolution method (agent, zk, operator, dns) instead of the
+default agent-then-zk fallback. --source=operator queries the Mesos Operator HTTP API at
+--operator-endpoint, and --source=dns resolves a _mesos._tcp SRV record under --dns-domain.`,
 	Run: fetchMesosLeader,
 }
 
@@ -195,13 +426,7 @@ func fetchTasksConfig(cmd *cobra.Command, args []string) {
 		log.Fatalf("error: %+v", err)
 	}
 
-	if toJson {
-		fmt.Println(internal.ToJSON(tasks))
-	} else {
-		for _, t := range tasks {
-			fmt.Println(t)
-		}
-	}
+	printFormatted(taskTable(tasks))
 }
 
 func fetchTasksStatus(cmd *cobra.Command, args []string) {
@@ -218,6 +443,23 @@ func fetchTasksStatus(cmd *cobra.Command, args []string) {
 	if *role == "" {
 		role = nil
 	}
+
+	if watch {
+		runWatch(watchInterval, watchOnChange, "AURORA_TASK_ID", pollTaskStatus)
+		return
+	}
+
+	tasks, err := getTaskStatus()
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+
+	printFormatted(taskTable(tasks))
+}
+
+// getTaskStatus runs the live-state task status query shared by
+// fetchTasksStatus and its --watch poller.
+func getTaskStatus() ([]*aurora.ScheduledTask, error) {
 	// TODO(rdelvalle): Add filtering down by status
 	taskQuery := &aurora.TaskQuery{
 		Environment: env,
@@ -225,18 +467,22 @@ func fetchTasksStatus(cmd *cobra.Command, args []string) {
 		JobName:     name,
 		Statuses:    aurora.LIVE_STATES}
 
-	tasks, err := client.GetTaskStatus(taskQuery)
+	return client.GetTaskStatus(taskQuery)
+}
+
+// pollTaskStatus is the --watch poller for taskStatusCmd: it snapshots
+// every live task's state, keyed by task id.
+func pollTaskStatus() (map[string]string, error) {
+	tasks, err := getTaskStatus()
 	if err != nil {
-		log.Fatalf("error: %+v", err)
+		return nil, err
 	}
 
-	if toJson {
-		fmt.Println(internal.ToJSON(tasks))
-	} else {
-		for _, t := range tasks {
-			fmt.Println(t)
-		}
+	snapshot := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		snapshot[taskID(t.GetAssignedTask())] = t.GetStatus().String()
 	}
+	return snapshot, nil
 }
 
 func fetchHostStatus(cmd *cobra.Command, args []string) {
@@ -246,13 +492,7 @@ func fetchHostStatus(cmd *cobra.Command, args []string) {
 		log.Fatalf("error: %+v\n", err)
 	}
 
-	if toJson {
-		fmt.Println(internal.ToJSON(result.Statuses))
-	} else {
-		for _, k := range result.GetStatuses() {
-			fmt.Printf("Result: %s:%s\n", k.Host, k.Mode)
-		}
-	}
+	printFormatted(hostStatusTable(result.GetStatuses()))
 }
 
 func fetchLeader(cmd *cobra.Command, args []string) {
@@ -262,59 +502,212 @@ func fetchLeader(cmd *cobra.Command, args []string) {
 		log.Fatalln("At least one Zookeeper node address must be passed in.")
 	}
 
-	url, err := realis.LeaderFromZKOpts(realis.ZKEndpoints(args...), realis.ZKPath(cmd.Flag("zkPath").Value.String()))
-
+	url, err := resolveAuroraLeader(args, cmd.Flag("zkPath").Value.String())
 	if err != nil {
 		log.Fatalf("error: %+v\n", err)
 	}
 
-	fmt.Println(url)
+	printFormatted(leaderResult{URL: url})
 }
 
+// resolveAuroraLeader resolves the leading Aurora scheduler's URL from the
+// given Zookeeper node(s) and election path.
+func resolveAuroraLeader(zkNodes []string, zkPath string) (string, error) {
+	return realis.LeaderFromZKOpts(realis.ZKEndpoints(zkNodes...), realis.ZKPath(zkPath))
+}
+
+// mesosSources are the valid values for the --source flag on mesosLeaderCmd.
+// When --source is left unset, only agent and then zk are tried.
+var mesosSources = []string{"agent", "zk", "operator", "dns"}
+
 func fetchMesosLeader(cmd *cobra.Command, args []string) {
-	if len(args) < 1 {
-		mesosAgentFlags, err := fetchMasterFromAgent(localAgentStateURL)
-		if err != nil || mesosAgentFlags.Master == "" {
+	agentOpts, err := mesosAgentOptsFromFlags(cmd)
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+
+	url, err := resolveMesosLeader(args, mesosLeaderOpts{
+		source:           cmd.Flag("source").Value.String(),
+		zkPath:           cmd.Flag("zkPath").Value.String(),
+		operatorEndpoint: cmd.Flag("operator-endpoint").Value.String(),
+		dnsDomain:        cmd.Flag("dns-domain").Value.String(),
+		agent:            agentOpts,
+	})
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+
+	printFormatted(leaderResult{URL: url})
+}
+
+// mesosLeaderOpts configures resolveMesosLeader.
+type mesosLeaderOpts struct {
+	source           string
+	zkPath           string
+	operatorEndpoint string
+	dnsDomain        string
+	agent            mesosAgentOpts
+}
+
+// resolveMesosLeader resolves the leading Mesos master address using the
+// method requested by opts.source, falling back to the local agent and then
+// Zookeeper when opts.source is left unset.
+func resolveMesosLeader(zkNodes []string, opts mesosLeaderOpts) (string, error) {
+	switch opts.source {
+	case "operator":
+		if opts.operatorEndpoint == "" {
+			return "", errors.New("--operator-endpoint must be set when --source=operator")
+		}
+		return mesosLeaderFromOperator(opts.operatorEndpoint)
+	case "dns":
+		if opts.dnsDomain == "" {
+			return "", errors.New("--dns-domain must be set when --source=dns")
+		}
+		return mesosLeaderFromDNS(opts.dnsDomain)
+	case "zk":
+		if len(zkNodes) < 1 {
+			return "", errors.New("at least one Zookeeper node address must be passed in when --source=zk")
+		}
+	case "agent", "":
+		// Fall through to the default agent-then-zk behavior below.
+	default:
+		return "", fmt.Errorf("unknown --source %q, must be one of: %s", opts.source, strings.Join(mesosSources, ", "))
+	}
+
+	if opts.source == "agent" || (opts.source == "" && len(zkNodes) < 1) {
+		agentFlags, err := fetchMasterFromAgent(opts.agent)
+		if err != nil || agentFlags.Master == "" {
+			if err == nil {
+				err = errors.New("local Mesos agent returned no master")
+			}
 			log.Debugf("unable to fetch Mesos leader via local Mesos agent: %v", err)
-			args = append(args, "localhost")
-		} else if mesosAgentFlags.hasMaster {
-			fmt.Println(mesosAgentFlags.Master)
-			return
+			if opts.source == "agent" {
+				return "", err
+			}
+			zkNodes = append(zkNodes, "localhost")
+		} else if agentFlags.hasMaster {
+			return agentFlags.Master, nil
 		} else {
-			args = append(args, strings.Split(mesosAgentFlags.Master, ",")...)
+			zkNodes = append(zkNodes, strings.Split(agentFlags.Master, ",")...)
 		}
 	}
-	log.Infof("Fetching Mesos-master leader from Zookeeper node(s): %v \n", args)
+	log.Infof("Fetching Mesos-master leader from Zookeeper node(s): %v \n", zkNodes)
 
-	url, err := realis.MesosFromZKOpts(realis.ZKEndpoints(args...), realis.ZKPath(cmd.Flag("zkPath").Value.String()))
+	return realis.MesosFromZKOpts(realis.ZKEndpoints(zkNodes...), realis.ZKPath(opts.zkPath))
+}
+
+// mesosOperatorGetMaster is the subset of the Operator HTTP API's
+// GET_MASTER response (https://mesos.apache.org/documentation/latest/operator-http-api/)
+// needed to resolve the leading master's address.
+type mesosOperatorGetMaster struct {
+	GetMaster struct {
+		MasterInfo struct {
+			Address struct {
+				Hostname string `json:"hostname"`
+				IP       string `json:"ip"`
+				Port     int    `json:"port"`
+			} `json:"address"`
+		} `json:"master_info"`
+	} `json:"get_master"`
+}
 
+// mesosLeaderFromOperator resolves the leading Mesos master by issuing a
+// GET_MASTER call against the Operator HTTP API exposed by the given
+// endpoint, e.g. http://mesos-master:5050/api/v1.
+func mesosLeaderFromOperator(endpoint string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString(`{"type":"GET_MASTER"}`))
 	if err != nil {
-		log.Fatalf("error: %+v\n", err)
+		return "", fmt.Errorf("unable to build operator API request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := timeoutHTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach operator API at %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("operator API at %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	var result mesosOperatorGetMaster
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("unable to decode operator API response: %w", err)
 	}
 
-	fmt.Println(url)
+	address := result.GetMaster.MasterInfo.Address
+	host := address.Hostname
+	if host == "" {
+		host = address.IP
+	}
+	if host == "" {
+		return "", errors.New("operator API response did not contain a master address")
+	}
+
+	return fmt.Sprintf("%s:%d", host, address.Port), nil
 }
 
-func fetchMasterFromAgent(url string) (mesosAgentFlags mesosAgentFlags, err error) {
-	resp, err := http.Get(url)
+// mesosLeaderFromDNS resolves the leading Mesos master by looking up the
+// "_mesos._tcp.<domain>" SRV record, the convention used by Mesos DNS.
+func mesosLeaderFromDNS(domain string) (string, error) {
+	_, records, err := net.LookupSRV("mesos", "tcp", domain)
 	if err != nil {
-		return
+		return "", fmt.Errorf("unable to look up _mesos._tcp.%s: %w", domain, err)
 	}
-	if resp.StatusCode != 200 {
-		return
+	if len(records) == 0 {
+		return "", fmt.Errorf("no SRV records found for _mesos._tcp.%s", domain)
+	}
+
+	leader := records[0]
+	return fmt.Sprintf("%s:%d", strings.TrimSuffix(leader.Target, "."), leader.Port), nil
+}
+
+func fetchMasterFromAgent(opts mesosAgentOpts) (mesosAgentFlags mesosAgentFlags, err error) {
+	agentURL := opts.url
+	if agentURL == "" {
+		agentURL = defaultAgentURL
+	}
+
+	req, err := http.NewRequest(http.MethodGet, agentURL, nil)
+	if err != nil {
+		return mesosAgentFlags, fmt.Errorf("unable to build request for %s: %w", agentURL, err)
+	}
+	setMesosAgentAuth(req, opts.auth)
+
+	resp, err := mesosAgentHTTPClient(opts).Do(req)
+	if err != nil {
+		return mesosAgentFlags, fmt.Errorf("unable to reach Mesos agent at %s: %w", agentURL, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return mesosAgentFlags, fmt.Errorf("Mesos agent at %s returned status %d", agentURL, resp.StatusCode)
+	}
+
 	state := &mesosAgentState{}
-	err = json.NewDecoder(resp.Body).Decode(state)
-	if err != nil {
-		return
+	if err := json.NewDecoder(resp.Body).Decode(state); err != nil {
+		return mesosAgentFlags, fmt.Errorf("unable to decode response from %s: %w", agentURL, err)
 	}
+
 	mesosAgentFlags = state.Flags
 	err = updateMasterFlag(&mesosAgentFlags)
-	return
+	return mesosAgentFlags, err
 }
 
+// maxMasterFileDepth caps how many file:// indirections updateMasterFlag
+// will follow before giving up, independent of the visited-path check
+// below (which only catches cycles, not long chains).
+const maxMasterFileDepth = 10
+
+// hostPortPattern matches a bare "host:port" master value.
+var hostPortPattern = regexp.MustCompile(`^[^\s/@]+:[0-9]+$`)
+
+// zkNodesPattern matches the "host1:port1,host2:port2,..." node list that
+// follows zk:// (and any embedded credentials).
+var zkNodesPattern = regexp.MustCompile(`^[^\s,:/@]+:[0-9]+(,[^\s,:/@]+:[0-9]+)*$`)
+
 /*
  Master flag can be passed as one of :
  host:port
@@ -324,28 +717,85 @@ func fetchMasterFromAgent(url string) (mesosAgentFlags mesosAgentFlags, err erro
  This function takes care of all the above cases and updates flags with parsed values
 */
 func updateMasterFlag(flags *mesosAgentFlags) error {
-	zkPathPrefix := "zk://"
-	filePathPrefix := "file://"
-	if strings.HasPrefix(flags.Master, zkPathPrefix) {
-		beginIndex := len(zkPathPrefix)
-		if strings.Contains(flags.Master, "@") {
-			beginIndex = strings.Index(flags.Master, "@") + 1
-		}
-		flags.Master = flags.Master[beginIndex:strings.LastIndex(flags.Master, "/")]
-	} else if strings.HasPrefix(flags.Master, filePathPrefix) {
-		content, err := ioutil.ReadFile(flags.Master)
+	return resolveMasterFlag(flags, map[string]bool{}, 0)
+}
+
+// resolveMasterFlag does the actual work behind updateMasterFlag, tracking
+// the absolute paths of file:// indirections it has already followed (to
+// catch symlink cycles that never repeat the literal string "file://") and
+// a hard depth cap (to bound long, non-cyclic chains).
+func resolveMasterFlag(flags *mesosAgentFlags, visited map[string]bool, depth int) error {
+	if depth > maxMasterFileDepth {
+		return fmt.Errorf("file:// master resolution exceeded depth %d, aborting", maxMasterFileDepth)
+	}
+
+	switch {
+	case strings.HasPrefix(flags.Master, "zk://"):
+		nodes, auth, err := parseZKMaster(flags.Master)
 		if err != nil {
 			return err
 		}
-		if strings.Contains(string(content), filePathPrefix) {
-			return errors.New("invalid master file content")
+		flags.Master = nodes
+		flags.Auth = auth
+		return nil
+
+	case strings.HasPrefix(flags.Master, "file://"):
+		path := strings.TrimPrefix(flags.Master, "file://")
+		if path == "" {
+			return errors.New("file:// master must include a path")
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("unable to resolve master file path %q: %w", path, err)
+		}
+		if visited[absPath] {
+			return fmt.Errorf("file:// master recursion detected at %q", absPath)
 		}
-		flags.Master = string(content)
-		return updateMasterFlag(flags)
-	} else {
+		visited[absPath] = true
+
+		content, err := ioutil.ReadFile(absPath)
+		if err != nil {
+			return err
+		}
+
+		flags.Master = strings.TrimSpace(string(content))
+		return resolveMasterFlag(flags, visited, depth+1)
+
+	case hostPortPattern.MatchString(flags.Master):
 		flags.hasMaster = true
+		return nil
+
+	default:
+		return fmt.Errorf("master %q does not match host:port, zk://, or file:// format", flags.Master)
+	}
+}
+
+// parseZKMaster validates and splits a "zk://[user:pass@]host1:port1,.../path"
+// master value into its Zookeeper node list and optional credentials.
+func parseZKMaster(master string) (nodes, auth string, err error) {
+	rest := strings.TrimPrefix(master, "zk://")
+
+	slash := strings.Index(rest, "/")
+	if slash == -1 {
+		return "", "", fmt.Errorf("zk:// master must include a path: %q", master)
+	}
+	hosts := rest[:slash]
+
+	// Credentials, if present, only ever appear before the path, so only look
+	// for "@" there - the path itself (e.g. a znode named "a@b") may contain
+	// one without it being a user:pass separator.
+	if at := strings.Index(hosts, "@"); at != -1 {
+		auth = hosts[:at]
+		hosts = hosts[at+1:]
+	}
+
+	nodes = hosts
+	if !zkNodesPattern.MatchString(nodes) {
+		return "", "", fmt.Errorf("invalid zk:// node list in %q", master)
 	}
-	return nil
+
+	return nodes, auth, nil
 }
 
 // TODO: Expand this to be able to filter by job name and environment.
@@ -361,23 +811,46 @@ func fetchJobs(cmd *cobra.Command, args []string) {
 		*role = ""
 	}
 
-	result, err := client.GetJobs(*role)
+	if watch {
+		runWatch(watchInterval, watchOnChange, "AURORA_JOB_KEY", pollJobs)
+		return
+	}
 
+	configSlice, err := getJobs()
 	if err != nil {
 		log.Fatalf("error: %+v", err)
 	}
 
-	if toJson {
-		var configSlice []*aurora.JobConfiguration
+	printFormatted(jobTable(configSlice))
+}
+
+// getJobs runs the job-listing query shared by fetchJobs and its --watch
+// poller.
+func getJobs() ([]*aurora.JobConfiguration, error) {
+	result, err := client.GetJobs(*role)
+	if err != nil {
+		return nil, err
+	}
+
+	var configSlice []*aurora.JobConfiguration
+	for _, config := range result.GetConfigs() {
+		configSlice = append(configSlice, config)
+	}
+	return configSlice, nil
+}
 
-		for _, config := range result.GetConfigs() {
-			configSlice = append(configSlice, config)
-		}
+// pollJobs is the --watch poller for fetchJobsCmd: it snapshots every job's
+// instance count, keyed by job key, so that instance count changes surface
+// as state_change events alongside added/removed jobs.
+func pollJobs() (map[string]string, error) {
+	jobs, err := getJobs()
+	if err != nil {
+		return nil, err
+	}
 
-		fmt.Println(internal.ToJSON(configSlice))
-	} else {
-		for jobConfig := range result.GetConfigs() {
-			fmt.Println(jobConfig)
-		}
+	snapshot := make(map[string]string, len(jobs))
+	for _, j := range jobs {
+		snapshot[j.GetKey().String()] = fmt.Sprintf("%d", j.GetInstanceCount())
 	}
+	return snapshot, nil
 }
@@ -0,0 +1,174 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseZKMaster(t *testing.T) {
+	cases := []struct {
+		name      string
+		master    string
+		wantNodes string
+		wantAuth  string
+		wantErr   bool
+	}{
+		{
+			name:      "no auth",
+			master:    "zk://host1:2181,host2:2181/aurora/scheduler",
+			wantNodes: "host1:2181,host2:2181",
+		},
+		{
+			name:      "with auth",
+			master:    "zk://user:pass@host1:2181/aurora/scheduler",
+			wantNodes: "host1:2181",
+			wantAuth:  "user:pass",
+		},
+		{
+			name:      "at sign in path, no auth",
+			master:    "zk://host1:2181,host2:2181/some@path",
+			wantNodes: "host1:2181,host2:2181",
+		},
+		{
+			name:    "missing path",
+			master:  "zk://host1:2181",
+			wantErr: true,
+		},
+		{
+			name:    "invalid node list",
+			master:  "zk://not a host list/aurora/scheduler",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			nodes, auth, err := parseZKMaster(c.master)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parseZKMaster(%q): expected error, got nil", c.master)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseZKMaster(%q): unexpected error: %v", c.master, err)
+			}
+			if nodes != c.wantNodes {
+				t.Errorf("parseZKMaster(%q): nodes = %q, want %q", c.master, nodes, c.wantNodes)
+			}
+			if auth != c.wantAuth {
+				t.Errorf("parseZKMaster(%q): auth = %q, want %q", c.master, auth, c.wantAuth)
+			}
+		})
+	}
+}
+
+func TestResolveMasterFlagHostPort(t *testing.T) {
+	flags := &mesosAgentFlags{Master: "host1:5050"}
+	if err := updateMasterFlag(flags); err != nil {
+		t.Fatalf("updateMasterFlag: unexpected error: %v", err)
+	}
+	if !flags.hasMaster {
+		t.Errorf("hasMaster = false, want true for a literal host:port master")
+	}
+}
+
+func TestResolveMasterFlagFileIndirection(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "master")
+	if err := ioutil.WriteFile(target, []byte("host1:5050\n"), 0o644); err != nil {
+		t.Fatalf("unable to write fixture file: %v", err)
+	}
+
+	flags := &mesosAgentFlags{Master: "file://" + target}
+	if err := updateMasterFlag(flags); err != nil {
+		t.Fatalf("updateMasterFlag: unexpected error: %v", err)
+	}
+	if flags.Master != "host1:5050" {
+		t.Errorf("Master = %q, want %q", flags.Master, "host1:5050")
+	}
+}
+
+func TestResolveMasterFlagFileRecursionDetected(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	b := filepath.Join(dir, "b")
+	if err := ioutil.WriteFile(a, []byte("file://"+b), 0o644); err != nil {
+		t.Fatalf("unable to write fixture file: %v", err)
+	}
+	if err := ioutil.WriteFile(b, []byte("file://"+a), 0o644); err != nil {
+		t.Fatalf("unable to write fixture file: %v", err)
+	}
+
+	flags := &mesosAgentFlags{Master: "file://" + a}
+	err := updateMasterFlag(flags)
+	if err == nil {
+		t.Fatal("updateMasterFlag: expected recursion error, got nil")
+	}
+}
+
+func TestResolveMasterFlagFileMissingPath(t *testing.T) {
+	flags := &mesosAgentFlags{Master: "file://"}
+	if err := updateMasterFlag(flags); err == nil {
+		t.Fatal("updateMasterFlag: expected error for empty file:// path, got nil")
+	}
+}
+
+func TestResolveMasterFlagDepthCap(t *testing.T) {
+	dir := t.TempDir()
+
+	// Build a chain of maxMasterFileDepth+2 distinct files, each pointing to
+	// the next, so no single path repeats and only the depth cap can stop it.
+	paths := make([]string, maxMasterFileDepth+2)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, "f"+string(rune('a'+i)))
+	}
+	for i := 0; i < len(paths)-1; i++ {
+		if err := ioutil.WriteFile(paths[i], []byte("file://"+paths[i+1]), 0o644); err != nil {
+			t.Fatalf("unable to write fixture file: %v", err)
+		}
+	}
+	if err := ioutil.WriteFile(paths[len(paths)-1], []byte("host1:5050"), 0o644); err != nil {
+		t.Fatalf("unable to write fixture file: %v", err)
+	}
+
+	flags := &mesosAgentFlags{Master: "file://" + paths[0]}
+	if err := updateMasterFlag(flags); err == nil {
+		t.Fatal("updateMasterFlag: expected depth-cap error, got nil")
+	}
+}
+
+func TestResolveMesosLeaderExplicitAgentSourceIgnoresZKNodes(t *testing.T) {
+	srv := httptest.NewServer(nil)
+	agentURL := srv.URL
+	srv.Close() // guarantees the agent fetch fails fast with connection refused
+
+	_, err := resolveMesosLeader([]string{"zk-host:2181"}, mesosLeaderOpts{
+		source: "agent",
+		agent:  mesosAgentOpts{url: agentURL},
+	})
+	if err == nil {
+		t.Fatal("resolveMesosLeader: expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "Mesos agent") {
+		t.Errorf("resolveMesosLeader: error = %q, want it to mention the Mesos agent fetch (not fall through to Zookeeper): zk-host:2181 should never be dialed when --source=agent", err.Error())
+	}
+}
+
@@ -0,0 +1,207 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	fetchMetricsCmd.Flags().String("zkPath", "/aurora/scheduler", "Zookeeper node path where Aurora leader election happens")
+	fetchMetricsCmd.Flags().String("mesos-source", "", fmt.Sprintf("Where to resolve the Mesos master from: %s (default: try agent then zk)", strings.Join(mesosSources, ", ")))
+	fetchMetricsCmd.Flags().String("mesos-operator-endpoint", "", "Mesos master endpoint to query via the Operator HTTP API (required when --mesos-source=operator)")
+	fetchMetricsCmd.Flags().String("mesos-dns-domain", "", "Domain to resolve _mesos._tcp SRV records against (required when --mesos-source=dns)")
+	fetchMetricsCmd.Flags().String("listen", "", "Address to serve Prometheus metrics on, e.g. :9100. When unset, metrics are scraped once and printed.")
+	addMesosAgentFlags(fetchMetricsCmd)
+	fetchCmd.AddCommand(fetchMetricsCmd)
+}
+
+var fetchMetricsCmd = &cobra.Command{
+	Use:   "metrics [zkNode0, zkNode1, ...zkNodeN]",
+	Short: "Scrape Aurora and Mesos metrics and expose them as Prometheus text.",
+	Long: `Pulls /vars.json from the current Aurora leader (resolved the same way as "fetch leader")
+and /metrics/snapshot from the Mesos master (resolved the same way as "fetch mesos leader"),
+normalizes them into a single metric set, and either prints them once or serves them
+continuously on --listen in Prometheus text exposition format.`,
+	PersistentPreRun:  func(cmd *cobra.Command, args []string) {}, // We don't need a realis client for this cmd
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {}, // We don't need a realis client for this cmd
+	PreRun:            setConfig,
+	Run:               fetchMetrics,
+}
+
+// metric is a single normalized Aurora/Mesos numeric sample.
+type metric struct {
+	Name  string
+	Value float64
+}
+
+func fetchMetrics(cmd *cobra.Command, args []string) {
+	listen := cmd.Flag("listen").Value.String()
+	if listen == "" {
+		auroraURL, mesosURL, err := resolveMetricsLeaders(cmd, args)
+		if err != nil {
+			log.Fatalf("error: %+v\n", err)
+		}
+		metrics, err := scrapeMetrics(auroraURL, mesosURL)
+		if err != nil {
+			log.Fatalf("error: %+v\n", err)
+		}
+		fmt.Print(renderPrometheus(metrics))
+		return
+	}
+
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		// Re-resolve the Aurora/Mesos leader on every scrape rather than once
+		// at startup, so a leader failover doesn't leave this exporter stuck
+		// scraping a stale, now-dead URL until it's restarted.
+		auroraURL, mesosURL, err := resolveMetricsLeaders(cmd, args)
+		if err != nil {
+			log.Errorf("error resolving leaders: %+v", err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		metrics, err := scrapeMetrics(auroraURL, mesosURL)
+		if err != nil {
+			log.Errorf("error scraping metrics: %+v", err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, renderPrometheus(metrics))
+	})
+
+	log.Infof("Serving Prometheus metrics on %s/metrics\n", listen)
+	log.Fatal(http.ListenAndServe(listen, nil))
+}
+
+// resolveMetricsLeaders resolves the current Aurora and Mesos leader URLs
+// from cmd's flags, the same way "fetch leader" and "fetch mesos leader" do.
+func resolveMetricsLeaders(cmd *cobra.Command, args []string) (auroraURL, mesosURL string, err error) {
+	auroraURL, err = resolveAuroraLeader(args, cmd.Flag("zkPath").Value.String())
+	if err != nil {
+		return "", "", err
+	}
+
+	agentOpts, err := mesosAgentOptsFromFlags(cmd)
+	if err != nil {
+		return "", "", err
+	}
+
+	mesosURL, err = resolveMesosLeader(args, mesosLeaderOpts{
+		source:           cmd.Flag("mesos-source").Value.String(),
+		zkPath:           "/mesos",
+		operatorEndpoint: cmd.Flag("mesos-operator-endpoint").Value.String(),
+		dnsDomain:        cmd.Flag("mesos-dns-domain").Value.String(),
+		agent:            agentOpts,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return auroraURL, mesosURL, nil
+}
+
+// scrapeMetrics pulls Aurora's /vars.json and Mesos's /metrics/snapshot and
+// normalizes both into a flat, sorted list of metrics.
+func scrapeMetrics(auroraURL, mesosURL string) ([]metric, error) {
+	auroraVars, err := fetchJSONMetrics(strings.TrimRight(auroraURL, "/") + "/vars.json")
+	if err != nil {
+		return nil, fmt.Errorf("unable to scrape Aurora vars from %s: %w", auroraURL, err)
+	}
+
+	// resolveMesosLeader yields a bare "host:port" for the agent, operator,
+	// and dns sources, so default to plain HTTP when no scheme is present.
+	if !strings.Contains(mesosURL, "://") {
+		mesosURL = "http://" + mesosURL
+	}
+	mesosVars, err := fetchJSONMetrics(strings.TrimRight(mesosURL, "/") + "/metrics/snapshot")
+	if err != nil {
+		return nil, fmt.Errorf("unable to scrape Mesos metrics from %s: %w", mesosURL, err)
+	}
+
+	metrics := make([]metric, 0, len(auroraVars)+len(mesosVars))
+	for name, value := range auroraVars {
+		metrics = append(metrics, metric{Name: "aurora_" + normalizeMetricName(name), Value: value})
+	}
+	for name, value := range mesosVars {
+		metrics = append(metrics, metric{Name: "mesos_" + normalizeMetricName(name), Value: value})
+	}
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+
+	return metrics, nil
+}
+
+// fetchJSONMetrics GETs a flat JSON object of numeric values, the format
+// used by both Aurora's /vars.json and Mesos's /metrics/snapshot.
+func fetchJSONMetrics(url string) (map[string]float64, error) {
+	resp, err := timeoutHTTPClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+
+	raw := make(map[string]json.Number)
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("unable to decode response from %s: %w", url, err)
+	}
+
+	values := make(map[string]float64, len(raw))
+	for name, number := range raw {
+		value, err := strconv.ParseFloat(number.String(), 64)
+		if err != nil {
+			log.Debugf("skipping non-numeric metric %s=%s from %s", name, number, url)
+			continue
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// normalizeMetricName converts Aurora/Mesos metric names (which use '-',
+// '.', and other separators) into a Prometheus-friendly snake_case name.
+func normalizeMetricName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return strings.ToLower(b.String())
+}
+
+// renderPrometheus formats metrics in Prometheus text exposition format.
+func renderPrometheus(metrics []metric) string {
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "%s %v\n", m.Name, m.Value)
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMetricName(t *testing.T) {
+	cases := map[string]string{
+		"jvm.gc.young-gen.time-ms": "jvm_gc_young_gen_time_ms",
+		"tasks_running":            "tasks_running",
+		"Uptime":                   "uptime",
+		"a/b c":                    "a_b_c",
+	}
+	for in, want := range cases {
+		if got := normalizeMetricName(in); got != want {
+			t.Errorf("normalizeMetricName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRenderPrometheus(t *testing.T) {
+	out := renderPrometheus([]metric{
+		{Name: "aurora_tasks_running", Value: 3},
+		{Name: "mesos_cpus_used", Value: 1.5},
+	})
+
+	want := "aurora_tasks_running 3\nmesos_cpus_used 1.5\n"
+	if out != want {
+		t.Errorf("renderPrometheus = %q, want %q", out, want)
+	}
+}
+
+func TestRenderPrometheusEmpty(t *testing.T) {
+	if out := renderPrometheus(nil); out != "" {
+		t.Errorf("renderPrometheus(nil) = %q, want empty string", out)
+	}
+}
+
+func TestRenderPrometheusLineFormat(t *testing.T) {
+	out := renderPrometheus([]metric{{Name: "x", Value: 1}})
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("renderPrometheus = %q, want it to end with a newline", out)
+	}
+}
@@ -0,0 +1,122 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+)
+
+// watchEvent is a single newline-delimited JSON event emitted by --watch
+// mode: an entity (task, job, ...) was added, removed, or changed state.
+type watchEvent struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	OldState string `json:"old_state,omitempty"`
+	NewState string `json:"new_state,omitempty"`
+}
+
+// runWatch polls poll() every interval, diffing the returned id->state
+// snapshot against the previous one, and emits a watchEvent for every
+// addition, removal, and state transition it finds. idEnv names the
+// environment variable the --on-change hook receives the entity id as,
+// e.g. "AURORA_TASK_ID" or "AURORA_JOB_KEY".
+func runWatch(interval time.Duration, onChange, idEnv string, poll func() (map[string]string, error)) {
+	prev := map[string]string{}
+	first := true
+
+	for {
+		curr, err := poll()
+		if err != nil {
+			log.Fatalf("error: %+v\n", err)
+		}
+
+		for _, ev := range diffWatchState(prev, curr, first) {
+			emitWatchEvent(onChange, idEnv, ev)
+		}
+
+		prev = curr
+		first = false
+		time.Sleep(interval)
+	}
+}
+
+// diffWatchState compares a previous id->state snapshot against the current
+// one and returns the watchEvents the transition implies: "added" for ids
+// new to curr (suppressed on the first poll, which has nothing to compare
+// against), "state_change" for ids whose state differs, and "removed" for
+// ids present in prev but missing from curr. Events are returned in a
+// deterministic, id-sorted order.
+func diffWatchState(prev, curr map[string]string, first bool) []watchEvent {
+	ids := make([]string, 0, len(prev)+len(curr))
+	seen := make(map[string]bool, len(prev)+len(curr))
+	for id := range curr {
+		ids = append(ids, id)
+		seen[id] = true
+	}
+	for id := range prev {
+		if !seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var events []watchEvent
+	for _, id := range ids {
+		newState, stillPresent := curr[id]
+		oldState, existed := prev[id]
+		switch {
+		case stillPresent && !existed && !first:
+			events = append(events, watchEvent{Type: "added", ID: id, NewState: newState})
+		case stillPresent && existed && oldState != newState:
+			events = append(events, watchEvent{Type: "state_change", ID: id, OldState: oldState, NewState: newState})
+		case !stillPresent && existed:
+			events = append(events, watchEvent{Type: "removed", ID: id, OldState: oldState})
+		}
+	}
+	return events
+}
+
+// emitWatchEvent prints ev as a line of NDJSON and, if onChange is set,
+// invokes it with AURORA_OLD_STATE/AURORA_NEW_STATE and the id under idEnv
+// set in its environment.
+func emitWatchEvent(onChange, idEnv string, ev watchEvent) {
+	out, err := json.Marshal(ev)
+	if err != nil {
+		log.Errorf("unable to marshal watch event: %v", err)
+		return
+	}
+	fmt.Println(string(out))
+
+	if onChange == "" {
+		return
+	}
+
+	c := exec.Command("sh", "-c", onChange)
+	c.Env = append(os.Environ(),
+		idEnv+"="+ev.ID,
+		"AURORA_OLD_STATE="+ev.OldState,
+		"AURORA_NEW_STATE="+ev.NewState,
+	)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		log.Warnf("--on-change hook failed: %v", err)
+	}
+}
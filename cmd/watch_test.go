@@ -0,0 +1,86 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffWatchStateFirstPollSuppressesAdded(t *testing.T) {
+	curr := map[string]string{"a": "RUNNING", "b": "PENDING"}
+	got := diffWatchState(map[string]string{}, curr, true)
+	if len(got) != 0 {
+		t.Errorf("diffWatchState on first poll = %v, want no events", got)
+	}
+}
+
+func TestDiffWatchStateAdded(t *testing.T) {
+	prev := map[string]string{"a": "RUNNING"}
+	curr := map[string]string{"a": "RUNNING", "b": "PENDING"}
+
+	got := diffWatchState(prev, curr, false)
+	want := []watchEvent{{Type: "added", ID: "b", NewState: "PENDING"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffWatchState = %v, want %v", got, want)
+	}
+}
+
+func TestDiffWatchStateStateChange(t *testing.T) {
+	prev := map[string]string{"a": "PENDING"}
+	curr := map[string]string{"a": "RUNNING"}
+
+	got := diffWatchState(prev, curr, false)
+	want := []watchEvent{{Type: "state_change", ID: "a", OldState: "PENDING", NewState: "RUNNING"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffWatchState = %v, want %v", got, want)
+	}
+}
+
+func TestDiffWatchStateRemoved(t *testing.T) {
+	prev := map[string]string{"a": "RUNNING", "b": "PENDING"}
+	curr := map[string]string{"a": "RUNNING"}
+
+	got := diffWatchState(prev, curr, false)
+	want := []watchEvent{{Type: "removed", ID: "b", OldState: "PENDING"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffWatchState = %v, want %v", got, want)
+	}
+}
+
+func TestDiffWatchStateNoChange(t *testing.T) {
+	prev := map[string]string{"a": "RUNNING"}
+	curr := map[string]string{"a": "RUNNING"}
+
+	got := diffWatchState(prev, curr, false)
+	if len(got) != 0 {
+		t.Errorf("diffWatchState on unchanged state = %v, want no events", got)
+	}
+}
+
+func TestDiffWatchStateOrderedByID(t *testing.T) {
+	prev := map[string]string{}
+	curr := map[string]string{"c": "RUNNING", "a": "RUNNING", "b": "RUNNING"}
+
+	got := diffWatchState(prev, curr, false)
+	var ids []string
+	for _, ev := range got {
+		ids = append(ids, ev.ID)
+	}
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("diffWatchState ids = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,73 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Tabular is implemented by values that know how to lay themselves out as a
+// table. Format falls back to this interface when the "table" output format
+// is requested.
+type Tabular interface {
+	// TableHeader returns the column names, in display order.
+	TableHeader() []string
+	// TableRows returns one row of cell values per record.
+	TableRows() [][]string
+}
+
+// Format renders v according to the requested output format: "json", "yaml",
+// or "table". An empty format defaults to "json" for backwards compatibility
+// with commands that used to hard-code JSON output.
+func Format(v interface{}, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", "json":
+		return ToJSON(v), nil
+	case "yaml":
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal to yaml: %w", err)
+		}
+		return string(out), nil
+	case "table":
+		t, ok := v.(Tabular)
+		if !ok {
+			return "", fmt.Errorf("table output is not supported for this command")
+		}
+		return renderTable(t.TableHeader(), t.TableRows()), nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q, must be one of: json, yaml, table", format)
+	}
+}
+
+// renderTable writes header and rows as a table whose columns are aligned
+// with spaces, the same layout used by tools like kubectl.
+func renderTable(header []string, rows [][]string) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	w.Flush()
+	return strings.TrimRight(buf.String(), "\n")
+}
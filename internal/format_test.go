@@ -0,0 +1,82 @@
+/**
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+type fakeTabular struct{}
+
+func (fakeTabular) TableHeader() []string { return []string{"name", "status"} }
+func (fakeTabular) TableRows() [][]string {
+	return [][]string{
+		{"job-a", "RUNNING"},
+		{"job-b", "PENDING"},
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	for _, format := range []string{"", "json"} {
+		out, err := Format(map[string]string{"key": "value"}, format)
+		if err != nil {
+			t.Fatalf("Format(%q): unexpected error: %v", format, err)
+		}
+		if !strings.Contains(out, `"key": "value"`) {
+			t.Errorf("Format(%q) = %q, want it to contain the marshaled field", format, out)
+		}
+	}
+}
+
+func TestFormatYAML(t *testing.T) {
+	out, err := Format(map[string]string{"key": "value"}, "yaml")
+	if err != nil {
+		t.Fatalf("Format(yaml): unexpected error: %v", err)
+	}
+	if strings.TrimSpace(out) != "key: value" {
+		t.Errorf("Format(yaml) = %q, want %q", out, "key: value")
+	}
+}
+
+func TestFormatTable(t *testing.T) {
+	out, err := Format(fakeTabular{}, "table")
+	if err != nil {
+		t.Fatalf("Format(table): unexpected error: %v", err)
+	}
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Format(table) has %d lines, want 3 (header + 2 rows): %q", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "NAME") {
+		t.Errorf("Format(table) header = %q, want it to start with the uppercased column name", lines[0])
+	}
+	if !strings.Contains(lines[1], "job-a") || !strings.Contains(lines[2], "job-b") {
+		t.Errorf("Format(table) = %q, want rows for job-a and job-b", out)
+	}
+}
+
+func TestFormatTableUnsupported(t *testing.T) {
+	if _, err := Format(map[string]string{"key": "value"}, "table"); err == nil {
+		t.Fatal("Format(table) on a non-Tabular value: expected error, got nil")
+	}
+}
+
+func TestFormatUnsupportedFormat(t *testing.T) {
+	if _, err := Format("v", "xml"); err == nil {
+		t.Fatal("Format(xml): expected error for unsupported format, got nil")
+	}
+}